@@ -4,6 +4,7 @@ package v8go
 // #include "v8go.h"
 import "C"
 import (
+	"context"
 	"fmt"
 	"runtime"
 	"sync"
@@ -33,8 +34,9 @@ type Context struct {
 }
 
 type contextOptions struct {
-	iso   *Isolate
-	gTmpl *ObjectTemplate
+	iso      *Isolate
+	gTmpl    *ObjectTemplate
+	snapshot *Snapshot
 }
 
 // ContextOption sets options such as Isolate and Global Template to the NewContext
@@ -42,6 +44,24 @@ type ContextOption interface {
 	apply(*contextOptions)
 }
 
+type withStartupSnapshot struct {
+	snapshot *Snapshot
+}
+
+func (o withStartupSnapshot) apply(opts *contextOptions) {
+	opts.snapshot = o.snapshot
+}
+
+// WithStartupSnapshot configures a new Context's Isolate, when the Context
+// creates its own Isolate (i.e. no other option supplies one), to begin
+// from the warm heap captured in s instead of V8's empty default heap. Cold
+// Context creation with a large stdlib can take hundreds of milliseconds;
+// a snapshot cuts that to sub-millisecond, which matters when handing out a
+// fresh isolate per request.
+func WithStartupSnapshot(s *Snapshot) ContextOption {
+	return withStartupSnapshot{snapshot: s}
+}
+
 // NewContext creates a new JavaScript context; if no Isolate is passed as a
 // ContextOption than a new Isolate will be created.
 func NewContext(opt ...ContextOption) (*Context, error) {
@@ -54,7 +74,7 @@ func NewContext(opt ...ContextOption) (*Context, error) {
 
 	if opts.iso == nil {
 		var err error
-		opts.iso, err = NewIsolate()
+		opts.iso, err = NewIsolateWithOptions(IsolateOptions{Snapshot: opts.snapshot})
 		if err != nil {
 			return nil, fmt.Errorf("v8go: failed to create new Isolate: %v", err)
 		}
@@ -99,15 +119,68 @@ func (c *Context) RunScript(source string, origin string) (*Value, error) {
 	rtn := C.RunScript(c.ptr, cSource, cOrigin)
 	c.deregister()
 
+	if oomErr := c.iso.takeOOMError(); oomErr != nil {
+		return nil, oomErr
+	}
+
 	return getValue(c, rtn), getError(rtn)
 }
 
+// RunScriptContext executes source like RunScript, but aborts the script via
+// (*Isolate).TerminateExecution if ctx is done before the script returns. If
+// that happens, RunScriptContext returns a *TerminatedError wrapping
+// ctx.Err(); the context's isolate remains usable afterwards. Unlike
+// RunScript, this gives callers real timeout/deadline support for scripts
+// that might otherwise hang forever (e.g. a runaway `while(true){}`).
+func (c *Context) RunScriptContext(ctx context.Context, source, origin string) (*Value, error) {
+	stop := make(chan struct{})
+	watcherDone := make(chan struct{})
+
+	var mu sync.Mutex
+	var finished, terminated bool
+
+	go func() {
+		defer close(watcherDone)
+		select {
+		case <-ctx.Done():
+			mu.Lock()
+			if !finished {
+				terminated = true
+				c.iso.TerminateExecution()
+			}
+			mu.Unlock()
+		case <-stop:
+		}
+	}()
+
+	val, err := c.RunScript(source, origin)
+
+	mu.Lock()
+	finished = true
+	done := terminated
+	mu.Unlock()
+
+	close(stop)
+	<-watcherDone
+
+	if done {
+		c.iso.cancelTerminateExecution()
+		return nil, &TerminatedError{Err: ctx.Err()}
+	}
+
+	return val, err
+}
+
 // Close will dispose the context and free the memory.
 func (c *Context) Close() {
 	c.finalizer()
 }
 
 func (c *Context) finalizer() {
+	modMutex.Lock()
+	delete(moduleResolvers, c.ref)
+	modMutex.Unlock()
+
 	C.ContextFree(c.ptr)
 	c.ptr = nil
 	runtime.SetFinalizer(c, nil)
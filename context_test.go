@@ -0,0 +1,59 @@
+package v8go
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRunScriptContextTimeout(t *testing.T) {
+	t.Parallel()
+
+	ctx, err := NewContext()
+	if err != nil {
+		t.Fatalf("NewContext: %v", err)
+	}
+
+	runCtx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = ctx.RunScriptContext(runCtx, "while (true) {}", "loop.js")
+
+	var termErr *TerminatedError
+	if !errors.As(err, &termErr) {
+		t.Fatalf("RunScriptContext error = %v, want *TerminatedError", err)
+	}
+	if !errors.Is(termErr.Err, context.DeadlineExceeded) {
+		t.Errorf("TerminatedError.Err = %v, want context.DeadlineExceeded", termErr.Err)
+	}
+
+	// The isolate must still be usable after termination.
+	val, err := ctx.RunScript("1 + 1", "main.js")
+	if err != nil {
+		t.Fatalf("RunScript after termination: %v", err)
+	}
+	if val == nil {
+		t.Fatal("RunScript after termination returned a nil value")
+	}
+}
+
+func TestRunScriptContextCompletesBeforeCancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, err := NewContext()
+	if err != nil {
+		t.Fatalf("NewContext: %v", err)
+	}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	val, err := ctx.RunScriptContext(runCtx, "1 + 1", "main.js")
+	if err != nil {
+		t.Fatalf("RunScriptContext: %v", err)
+	}
+	if val == nil {
+		t.Fatal("RunScriptContext returned a nil value for a completed script")
+	}
+}
@@ -0,0 +1,34 @@
+package v8go
+
+import "fmt"
+
+// OOMError is returned in place of the usual JSError when a script is
+// terminated because its isolate came too close to its heap limit (see the
+// near-heap-limit handling in NewIsolateWithOptions). Unlike a genuine V8
+// fatal error -- which is unrecoverable and takes the whole process down
+// before Go ever gets a chance to see this error -- the isolate that
+// produced an OOMError for a heap limit is still alive and safe to reuse.
+type OOMError struct {
+	// Location is where V8 detected the condition.
+	Location string
+	// IsHeap reports whether this was a JS heap OOM, as opposed to a
+	// process-wide allocation failure.
+	IsHeap  bool
+	Message string
+}
+
+func (e *OOMError) Error() string {
+	return fmt.Sprintf("v8go: fatal error at %s: %s", e.Location, e.Message)
+}
+
+// TerminatedError is returned by RunScriptContext when the context.Context
+// passed to it is done before the script finishes running.
+type TerminatedError struct {
+	Err error
+}
+
+func (e *TerminatedError) Error() string {
+	return fmt.Sprintf("v8go: script execution terminated: %v", e.Err)
+}
+
+func (e *TerminatedError) Unwrap() error { return e.Err }
@@ -0,0 +1,50 @@
+package v8go
+
+import "testing"
+
+// withIsolateOpt pins a Context to a pre-built Isolate, the same way
+// withStartupSnapshot in context.go pins one to a Snapshot; it only exists
+// for tests that need to observe an Isolate they configured themselves.
+type withIsolateOpt struct{ iso *Isolate }
+
+func (o withIsolateOpt) apply(opts *contextOptions) { opts.iso = o.iso }
+
+func TestRunScriptOOM(t *testing.T) {
+	t.Parallel()
+
+	iso, err := NewIsolateWithOptions(IsolateOptions{MaxOldSpaceSize: 4})
+	if err != nil {
+		t.Fatalf("NewIsolateWithOptions: %v", err)
+	}
+
+	var gotLocation string
+	var gotIsHeap bool
+	iso.SetOOMErrorHandler(func(location string, isHeap bool) {
+		gotLocation = location
+		gotIsHeap = isHeap
+	})
+
+	ctx, err := NewContext(withIsolateOpt{iso: iso})
+	if err != nil {
+		t.Fatalf("NewContext: %v", err)
+	}
+
+	_, err = ctx.RunScript(`
+		var xs = [];
+		while (true) { xs.push(new Array(1e6).fill(0)); }
+	`, "oom.js")
+
+	oomErr, ok := err.(*OOMError)
+	if !ok {
+		t.Fatalf("RunScript error = %T(%v), want *OOMError", err, err)
+	}
+	if !oomErr.IsHeap {
+		t.Error("OOMError.IsHeap = false, want true")
+	}
+	if gotLocation == "" {
+		t.Error("SetOOMErrorHandler callback was not invoked")
+	}
+	if !gotIsHeap {
+		t.Error("SetOOMErrorHandler isHeap = false, want true")
+	}
+}
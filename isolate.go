@@ -0,0 +1,260 @@
+package v8go
+
+// #include <stdlib.h>
+// #include "v8go.h"
+import "C"
+import (
+	"runtime"
+	"sync"
+	"unsafe"
+)
+
+// Due to the limitations of passing pointers to C from Go we need to create
+// a registry so that OOM/fatal-error callbacks from V8 can find their way
+// back to the Isolate that raised them. This mirrors ctxRegistry in
+// context.go.
+var isoMutex sync.RWMutex
+var isoRegistry = make(map[int]*Isolate)
+var isoSeq = 0
+
+// Isolate is a JavaScript VM instance with its own heap and garbage
+// collector. Most applications will create one isolate per thread/goroutine
+// that will use it; a single isolate must not be used concurrently from
+// multiple goroutines without external synchronization.
+type Isolate struct {
+	ref int
+	ptr C.IsolatePtr
+
+	// snapshotData backs CreateParams.snapshot_blob in the C++ layer, which
+	// V8 reads lazily -- not at Isolate::New, but later when Context::New
+	// deserializes the default context. It must outlive the isolate, so it
+	// is freed in finalizer rather than right after NewIsolateWithConstraints
+	// returns.
+	snapshotData *C.char
+
+	oomMu      sync.Mutex
+	oomErr     *OOMError
+	oomHandler func(location string, isHeap bool)
+}
+
+type isolateOptions struct {
+	constraints C.IsolateConstraints
+	snapshot    *Snapshot
+}
+
+// IsolateOption fills in the settings a new Isolate is created with.
+// IsolateOptions is its only implementation and is applied by
+// NewIsolateWithOptions; NewIsolate takes no options.
+type IsolateOption interface {
+	apply(*isolateOptions)
+}
+
+// IsolateOptions bounds the amount of native memory an Isolate may use.
+// All sizes are in MiB; a zero value leaves V8's own default in place.
+// This is the primary tool for safely embedding untrusted JavaScript, since
+// without it a single script can consume unbounded host memory.
+type IsolateOptions struct {
+	// MaxOldSpaceSize caps the size of the old generation heap.
+	MaxOldSpaceSize int
+	// MaxYoungGenerationSize caps the size of the young generation (nursery) heap.
+	MaxYoungGenerationSize int
+	// InitialOldSpaceSize sets the initial size of the old generation heap.
+	InitialOldSpaceSize int
+	// InitialYoungGenerationSize sets the initial size of the young generation heap.
+	InitialYoungGenerationSize int
+	// CodeRangeSize caps the size of the range of virtual memory used for
+	// generated code.
+	CodeRangeSize int
+	// Snapshot, if set, is the startup snapshot this Isolate's heap begins
+	// from, instead of V8's empty default heap. This is the IsolateOptions
+	// equivalent of the ContextOption WithStartupSnapshot, for callers that
+	// construct their Isolate directly via NewIsolateWithOptions.
+	Snapshot *Snapshot
+}
+
+func (o IsolateOptions) apply(opts *isolateOptions) {
+	opts.constraints = C.IsolateConstraints{
+		max_old_space_size:            C.int(o.MaxOldSpaceSize),
+		max_young_generation_size:     C.int(o.MaxYoungGenerationSize),
+		initial_old_space_size:        C.int(o.InitialOldSpaceSize),
+		initial_young_generation_size: C.int(o.InitialYoungGenerationSize),
+		code_range_size:               C.int(o.CodeRangeSize),
+	}
+	opts.snapshot = o.Snapshot
+}
+
+// NewIsolate creates a new V8 isolate using V8's default resource
+// constraints. Only one thread may access a given isolate at a time; if you
+// want to use the same isolate from multiple goroutines you must implement
+// your own locking.
+func NewIsolate() (*Isolate, error) {
+	return NewIsolateWithOptions(IsolateOptions{})
+}
+
+// NewIsolateWithOptions creates a new V8 isolate, applying opts to V8's
+// ResourceConstraints before the isolate is created. Use this to bound the
+// amount of memory a script running in this isolate (and the contexts
+// created from it) may consume.
+func NewIsolateWithOptions(opts IsolateOptions) (*Isolate, error) {
+	o := isolateOptions{}
+	opts.apply(&o)
+
+	var snapshotData *C.char
+	var snapshotLen C.size_t
+	if o.snapshot != nil && len(o.snapshot.blob) > 0 {
+		snapshotData = (*C.char)(C.CBytes(o.snapshot.blob))
+		snapshotLen = C.size_t(len(o.snapshot.blob))
+	}
+
+	isoMutex.Lock()
+	isoSeq++
+	ref := isoSeq
+	isoMutex.Unlock()
+
+	iso := &Isolate{
+		ref:          ref,
+		ptr:          C.NewIsolateWithConstraints(o.constraints, C.int(ref), snapshotData, snapshotLen),
+		snapshotData: snapshotData,
+	}
+
+	isoMutex.Lock()
+	isoRegistry[ref] = iso
+	isoMutex.Unlock()
+
+	runtime.SetFinalizer(iso, (*Isolate).finalizer)
+	// TODO: [RC] catch any C++ exceptions and return as error
+	return iso, nil
+}
+
+// SetOOMErrorHandler registers cb to be called whenever this isolate comes
+// close enough to its heap limit that the running script is terminated to
+// recover it (see the near-heap-limit handling in NewIsolateWithOptions).
+// This is in addition to, not instead of, the *OOMError that RunScript
+// already returns; use it when you need to react (e.g. log, alert) as soon
+// as the condition is hit rather than waiting for the call that triggered it
+// to return.
+func (i *Isolate) SetOOMErrorHandler(cb func(location string, isHeap bool)) {
+	i.oomMu.Lock()
+	i.oomHandler = cb
+	i.oomMu.Unlock()
+}
+
+func (i *Isolate) setPendingOOMError(err *OOMError) {
+	i.oomMu.Lock()
+	i.oomErr = err
+	i.oomMu.Unlock()
+}
+
+// takeOOMError returns and clears the OOM/fatal error recorded for this
+// isolate, if any, since the last call.
+func (i *Isolate) takeOOMError() *OOMError {
+	i.oomMu.Lock()
+	defer i.oomMu.Unlock()
+	err := i.oomErr
+	i.oomErr = nil
+	return err
+}
+
+func getIsolate(ref int) *Isolate {
+	isoMutex.RLock()
+	defer isoMutex.RUnlock()
+	return isoRegistry[ref]
+}
+
+//export goOnOOMError
+func goOnOOMError(ref C.int, location *C.char, isHeapOOM C.int) {
+	iso := getIsolate(int(ref))
+	if iso == nil {
+		return
+	}
+	iso.setPendingOOMError(&OOMError{
+		Location: C.GoString(location),
+		IsHeap:   isHeapOOM != 0,
+		Message:  "V8 isolate ran out of memory",
+	})
+
+	iso.oomMu.Lock()
+	handler := iso.oomHandler
+	iso.oomMu.Unlock()
+	if handler != nil {
+		handler(C.GoString(location), isHeapOOM != 0)
+	}
+}
+
+//export goOnFatalError
+func goOnFatalError(ref C.int, location, message *C.char) {
+	iso := getIsolate(int(ref))
+	if iso == nil {
+		return
+	}
+	iso.setPendingOOMError(&OOMError{
+		Location: C.GoString(location),
+		Message:  C.GoString(message),
+	})
+}
+
+// HeapStatistics represents V8 isolate heap statistics, mirroring
+// v8::HeapStatistics. Use it alongside IsolateOptions to observe memory
+// pressure at runtime.
+type HeapStatistics struct {
+	TotalHeapSize            uint64
+	TotalHeapSizeExecutable  uint64
+	TotalPhysicalSize        uint64
+	TotalAvailableSize       uint64
+	UsedHeapSize             uint64
+	HeapSizeLimit            uint64
+	MallocedMemory           uint64
+	ExternalMemory           uint64
+	PeakMallocedMemory       uint64
+	NumberOfNativeContexts   uint64
+	NumberOfDetachedContexts uint64
+}
+
+// GetHeapStatistics returns heap statistics for this isolate.
+func (i *Isolate) GetHeapStatistics() HeapStatistics {
+	stats := C.IsolateGetHeapStatistics(i.ptr)
+	return HeapStatistics{
+		TotalHeapSize:            uint64(stats.total_heap_size),
+		TotalHeapSizeExecutable:  uint64(stats.total_heap_size_executable),
+		TotalPhysicalSize:        uint64(stats.total_physical_size),
+		TotalAvailableSize:       uint64(stats.total_available_size),
+		UsedHeapSize:             uint64(stats.used_heap_size),
+		HeapSizeLimit:            uint64(stats.heap_size_limit),
+		MallocedMemory:           uint64(stats.malloced_memory),
+		ExternalMemory:           uint64(stats.external_memory),
+		PeakMallocedMemory:       uint64(stats.peak_malloced_memory),
+		NumberOfNativeContexts:   uint64(stats.number_of_native_contexts),
+		NumberOfDetachedContexts: uint64(stats.number_of_detached_contexts),
+	}
+}
+
+// TerminateExecution schedules an exception to be thrown, aborting any
+// script currently running in this isolate. It is safe to call from any
+// goroutine, including one other than the one running the script. See also
+// (*Context).RunScriptContext, which calls this automatically when its
+// context.Context is done.
+func (i *Isolate) TerminateExecution() {
+	C.IsolateTerminateExecution(i.ptr)
+}
+
+// cancelTerminateExecution reverses a prior TerminateExecution call so that
+// the isolate can run scripts again.
+func (i *Isolate) cancelTerminateExecution() {
+	C.IsolateCancelTerminateExecution(i.ptr)
+}
+
+func (i *Isolate) finalizer() {
+	isoMutex.Lock()
+	delete(isoRegistry, i.ref)
+	isoMutex.Unlock()
+
+	C.IsolateDispose(i.ptr)
+	i.ptr = nil
+
+	if i.snapshotData != nil {
+		C.free(unsafe.Pointer(i.snapshotData))
+		i.snapshotData = nil
+	}
+
+	runtime.SetFinalizer(i, nil)
+}
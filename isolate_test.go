@@ -0,0 +1,44 @@
+package v8go
+
+import "testing"
+
+func TestNewIsolateWithOptions(t *testing.T) {
+	t.Parallel()
+
+	iso, err := NewIsolateWithOptions(IsolateOptions{
+		MaxOldSpaceSize:        16,
+		MaxYoungGenerationSize: 4,
+	})
+	if err != nil {
+		t.Fatalf("NewIsolateWithOptions: %v", err)
+	}
+
+	stats := iso.GetHeapStatistics()
+	if stats.HeapSizeLimit == 0 {
+		t.Fatal("HeapSizeLimit = 0, want a limit derived from MaxOldSpaceSize")
+	}
+}
+
+func TestIsolateGetHeapStatistics(t *testing.T) {
+	t.Parallel()
+
+	ctx, err := NewContext()
+	if err != nil {
+		t.Fatalf("NewContext: %v", err)
+	}
+	iso, err := ctx.Isolate()
+	if err != nil {
+		t.Fatalf("Isolate: %v", err)
+	}
+
+	before := iso.GetHeapStatistics()
+
+	if _, err := ctx.RunScript("var xs = new Array(1e5).fill(0)", "main.js"); err != nil {
+		t.Fatalf("RunScript: %v", err)
+	}
+
+	after := iso.GetHeapStatistics()
+	if after.UsedHeapSize < before.UsedHeapSize {
+		t.Errorf("UsedHeapSize went down after allocating (%d -> %d)", before.UsedHeapSize, after.UsedHeapSize)
+	}
+}
@@ -0,0 +1,135 @@
+package v8go
+
+// #include <stdlib.h>
+// #include "v8go.h"
+import "C"
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"unsafe"
+)
+
+// Module represents a compiled ES module, as returned by
+// (*Context).CompileModule. Before it can be evaluated it must be
+// instantiated with InstantiateModule, which resolves its imports.
+type Module struct {
+	ptr C.ModulePtr
+	ctx *Context
+}
+
+// ModuleResolveCallback resolves the module identified by specifier, as
+// imported by referrer (the specifier of the importing module or, for a
+// dynamic import() in a classic script, that script's origin), returning
+// the compiled Module that satisfies the import.
+type ModuleResolveCallback func(specifier, referrer string) (*Module, error)
+
+// moduleResolvers holds the resolver passed to InstantiateModule, keyed by
+// the same Context ref that ctxRegistry in context.go already uses. V8
+// invokes resolution both while instantiating a module's static imports and,
+// later, for any dynamic import() evaluated within that Context, so the
+// resolver is kept for the Context's lifetime rather than just one call.
+var modMutex sync.RWMutex
+var moduleResolvers = make(map[int]ModuleResolveCallback)
+
+// CompileModule compiles source as an ES module; specifier identifies the
+// module to later imports and is used in stack traces the same way origin
+// is for RunScript.
+func (c *Context) CompileModule(source, specifier string) (*Module, error) {
+	cSource := C.CString(source)
+	cSpecifier := C.CString(specifier)
+	defer C.free(unsafe.Pointer(cSource))
+	defer C.free(unsafe.Pointer(cSpecifier))
+
+	rtn := C.CompileModule(c.ptr, cSource, cSpecifier)
+	if err := moduleError(rtn.error); err != nil {
+		return nil, err
+	}
+
+	m := &Module{ptr: rtn.module, ctx: c}
+	runtime.SetFinalizer(m, (*Module).finalizer)
+	return m, nil
+}
+
+// InstantiateModule resolves all of m's imports using resolver, which V8
+// also invokes to resolve any dynamic import() expression evaluated later
+// within m's Context.
+func (m *Module) InstantiateModule(resolver ModuleResolveCallback) error {
+	modMutex.Lock()
+	moduleResolvers[m.ctx.ref] = resolver
+	modMutex.Unlock()
+
+	return moduleError(C.InstantiateModule(m.ctx.ptr, m.ptr))
+}
+
+// Evaluate runs m, which must already have been instantiated, returning its
+// module namespace object.
+func (m *Module) Evaluate() (*Value, error) {
+	rtn := C.EvaluateModule(m.ctx.ptr, m.ptr)
+	return getValue(m.ctx, rtn), getError(rtn)
+}
+
+func (m *Module) finalizer() {
+	C.ModuleFree(m.ctx.ptr, m.ptr)
+	m.ptr = nil
+	runtime.SetFinalizer(m, nil)
+}
+
+func moduleError(e C.RtnError) error {
+	if e.msg == nil {
+		return nil
+	}
+	err := &JSError{
+		Message:    C.GoString(e.msg),
+		Location:   C.GoString(e.location),
+		StackTrace: C.GoString(e.stack),
+	}
+	C.free(unsafe.Pointer(e.msg))
+	C.free(unsafe.Pointer(e.location))
+	C.free(unsafe.Pointer(e.stack))
+	return err
+}
+
+// goResolveModule is called from v8go.cc's ResolveModuleCallback and
+// HostImportModuleDynamically to run the Go ModuleResolveCallback. Its
+// result carries an error (in the same RtnError shape CompileModule and
+// friends already return) rather than a bare nil ModulePtr, because V8
+// requires every module-resolve callback that returns empty to have
+// scheduled a pending exception first; a resolver failure or an unknown
+// specifier must reach the caller, not be silently swallowed.
+//
+//export goResolveModule
+func goResolveModule(ctxRef C.int, cSpecifier, cReferrer *C.char) C.RtnModule {
+	rtn := C.RtnModule{}
+
+	modMutex.RLock()
+	resolver := moduleResolvers[int(ctxRef)]
+	modMutex.RUnlock()
+	if resolver == nil {
+		rtn.error = resolveError(fmt.Sprintf(
+			"v8go: no module resolver registered for specifier %q", C.GoString(cSpecifier)))
+		return rtn
+	}
+
+	resolved, err := resolver(C.GoString(cSpecifier), C.GoString(cReferrer))
+	if err != nil {
+		rtn.error = resolveError(err.Error())
+		return rtn
+	}
+	if resolved == nil {
+		rtn.error = resolveError(fmt.Sprintf(
+			"v8go: module resolver returned no module for specifier %q", C.GoString(cSpecifier)))
+		return rtn
+	}
+
+	rtn.module = resolved.ptr
+	return rtn
+}
+
+// resolveError builds an RtnError carrying msg for the C++ side to turn
+// into a JS exception; msg is allocated with C.CString, so the C++ caller
+// is responsible for freeing it once it has been used, the same convention
+// RunScript's errors already follow in the other direction.
+func resolveError(msg string) C.RtnError {
+	return C.RtnError{msg: C.CString(msg)}
+}
@@ -0,0 +1,73 @@
+package v8go
+
+import "testing"
+
+func TestModuleResolveCallback(t *testing.T) {
+	t.Parallel()
+
+	ctx, err := NewContext()
+	if err != nil {
+		t.Fatalf("NewContext: %v", err)
+	}
+
+	dep, err := ctx.CompileModule(`export const answer = 42;`, "dep.js")
+	if err != nil {
+		t.Fatalf("CompileModule(dep): %v", err)
+	}
+
+	main, err := ctx.CompileModule(
+		`import { answer } from "dep.js"; export const result = answer;`, "main.js")
+	if err != nil {
+		t.Fatalf("CompileModule(main): %v", err)
+	}
+
+	var resolvedSpecifier, resolvedReferrer string
+	resolver := func(specifier, referrer string) (*Module, error) {
+		resolvedSpecifier = specifier
+		resolvedReferrer = referrer
+		if specifier == "dep.js" {
+			return dep, nil
+		}
+		return nil, nil
+	}
+
+	if err := main.InstantiateModule(resolver); err != nil {
+		t.Fatalf("InstantiateModule: %v", err)
+	}
+
+	if _, err := main.Evaluate(); err != nil {
+		t.Fatalf("Evaluate: %v", err)
+	}
+
+	if resolvedSpecifier != "dep.js" {
+		t.Errorf("resolver specifier = %q, want %q", resolvedSpecifier, "dep.js")
+	}
+	if resolvedReferrer != "main.js" {
+		t.Errorf("resolver referrer = %q, want %q", resolvedReferrer, "main.js")
+	}
+}
+
+func TestModuleResolveCallbackUnknownSpecifier(t *testing.T) {
+	t.Parallel()
+
+	ctx, err := NewContext()
+	if err != nil {
+		t.Fatalf("NewContext: %v", err)
+	}
+
+	main, err := ctx.CompileModule(`import "missing.js";`, "main.js")
+	if err != nil {
+		t.Fatalf("CompileModule(main): %v", err)
+	}
+
+	resolver := func(specifier, referrer string) (*Module, error) {
+		return nil, nil
+	}
+
+	// A resolver that can't find the specifier must surface as a catchable
+	// error, not crash the process (V8 requires a pending exception
+	// whenever the resolve callback returns empty).
+	if err := main.InstantiateModule(resolver); err == nil {
+		t.Fatal("InstantiateModule with an unresolvable import returned nil error")
+	}
+}
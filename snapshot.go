@@ -0,0 +1,53 @@
+package v8go
+
+// #include <stdlib.h>
+// #include "v8go.h"
+import "C"
+import "unsafe"
+
+// Snapshot is a serialized V8 heap, produced by CreateSnapshot, that can be
+// handed to WithStartupSnapshot (or IsolateOptions.Snapshot) so that every
+// Isolate/Context built from it begins with that heap already materialized,
+// instead of paying to rebuild it (e.g. by re-executing a large library
+// like lodash) on every startup.
+type Snapshot struct {
+	blob []byte
+}
+
+// CreateSnapshot compiles and runs source in a fresh, throwaway isolate,
+// then serializes the resulting heap into a Snapshot. origin is used the
+// same way as in RunScript.
+func CreateSnapshot(source, origin string) (*Snapshot, error) {
+	cSource := C.CString(source)
+	cOrigin := C.CString(origin)
+	defer C.free(unsafe.Pointer(cSource))
+	defer C.free(unsafe.Pointer(cOrigin))
+
+	rtn := C.CreateSnapshot(cSource, cOrigin)
+	if rtn.error.msg != nil {
+		err := &JSError{
+			Message:    C.GoString(rtn.error.msg),
+			Location:   C.GoString(rtn.error.location),
+			StackTrace: C.GoString(rtn.error.stack),
+		}
+		C.free(unsafe.Pointer(rtn.error.msg))
+		C.free(unsafe.Pointer(rtn.error.location))
+		C.free(unsafe.Pointer(rtn.error.stack))
+		return nil, err
+	}
+	defer C.free(unsafe.Pointer(rtn.data))
+
+	return &Snapshot{blob: C.GoBytes(unsafe.Pointer(rtn.data), C.int(rtn.len))}, nil
+}
+
+// Serialize returns the raw snapshot blob, suitable for caching to disk (or
+// in memory) and later reconstructing with NewSnapshotFromBlob.
+func (s *Snapshot) Serialize() []byte {
+	return s.blob
+}
+
+// NewSnapshotFromBlob wraps a previously serialized blob, as produced by
+// (*Snapshot).Serialize, back into a Snapshot.
+func NewSnapshotFromBlob(blob []byte) *Snapshot {
+	return &Snapshot{blob: blob}
+}
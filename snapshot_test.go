@@ -0,0 +1,40 @@
+package v8go
+
+import "testing"
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	snap, err := CreateSnapshot("globalThis.preloaded = 21 * 2;", "snapshot.js")
+	if err != nil {
+		t.Fatalf("CreateSnapshot: %v", err)
+	}
+
+	blob := snap.Serialize()
+	if len(blob) == 0 {
+		t.Fatal("Serialize returned an empty blob")
+	}
+
+	restored := NewSnapshotFromBlob(blob)
+
+	ctx, err := NewContext(WithStartupSnapshot(restored))
+	if err != nil {
+		t.Fatalf("NewContext: %v", err)
+	}
+
+	val, err := ctx.RunScript("preloaded", "main.js")
+	if err != nil {
+		t.Fatalf("RunScript: %v", err)
+	}
+	if got := val.String(); got != "42" {
+		t.Errorf("preloaded = %q, want %q", got, "42")
+	}
+}
+
+func TestCreateSnapshotError(t *testing.T) {
+	t.Parallel()
+
+	if _, err := CreateSnapshot("!@#$(", "broken.js"); err == nil {
+		t.Fatal("CreateSnapshot with invalid source returned nil error")
+	}
+}